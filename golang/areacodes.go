@@ -0,0 +1,98 @@
+package rts
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+//go:embed areacodes.csv
+var defaultAreaCodesCSV []byte
+
+// AreaInfo is the human-readable location an AreaIntensity.Code resolves
+// to: a county/city-level area name plus its representative coordinates.
+//
+// The bundled default dataset covers Taiwan's 22 counties/cities as a
+// starting point; callers needing the full CWA township-level mapping
+// should load it with LoadAreaCodes.
+type AreaInfo struct {
+	Name       string  `json:"name"`
+	CountyName string  `json:"county_name"`
+	Lat        float64 `json:"lat"`
+	Lon        float64 `json:"lon"`
+}
+
+// AreaCodeDB resolves AreaIntensity.Code values to an AreaInfo.
+type AreaCodeDB struct {
+	areas map[uint16]AreaInfo
+}
+
+// DefaultAreaCodeDB loads the dataset embedded with the package.
+func DefaultAreaCodeDB() (*AreaCodeDB, error) {
+	return LoadAreaCodes(bytes.NewReader(defaultAreaCodesCSV))
+}
+
+// LoadAreaCodes reads a CSV area code mapping with a header row
+// "code,name,county_name,lat,lon", e.g. to supply an authoritative or
+// overridden dataset in place of the bundled default.
+func LoadAreaCodes(r io.Reader) (*AreaCodeDB, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("rts: read area codes: %w", err)
+	}
+	if len(rows) == 0 {
+		return &AreaCodeDB{areas: map[uint16]AreaInfo{}}, nil
+	}
+
+	// skip the header row
+	areas := make(map[uint16]AreaInfo, len(rows)-1)
+	for _, row := range rows[1:] {
+		if len(row) != 5 {
+			return nil, fmt.Errorf("rts: area code row %q: want 5 columns, got %d", row, len(row))
+		}
+
+		code, err := strconv.ParseUint(row[0], 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("rts: area code %q: %w", row[0], err)
+		}
+
+		lat, err := strconv.ParseFloat(row[3], 64)
+		if err != nil {
+			return nil, fmt.Errorf("rts: area lat %q: %w", row[3], err)
+		}
+
+		lon, err := strconv.ParseFloat(row[4], 64)
+		if err != nil {
+			return nil, fmt.Errorf("rts: area lon %q: %w", row[4], err)
+		}
+
+		areas[uint16(code)] = AreaInfo{
+			Name:       row[1],
+			CountyName: row[2],
+			Lat:        lat,
+			Lon:        lon,
+		}
+	}
+
+	return &AreaCodeDB{areas: areas}, nil
+}
+
+// Lookup resolves code to its AreaInfo, reporting false if code isn't in
+// the database.
+func (db *AreaCodeDB) Lookup(code uint16) (AreaInfo, bool) {
+	info, ok := db.areas[code]
+	return info, ok
+}
+
+// Enrich fills in Info on each of data's AreaIntensities using db,
+// leaving codes db has no entry for untouched.
+func Enrich(data *RTSData, db *AreaCodeDB) {
+	for i, areaInt := range data.AreaIntensities {
+		if info, ok := db.Lookup(areaInt.Code); ok {
+			data.AreaIntensities[i].Info = &info
+		}
+	}
+}
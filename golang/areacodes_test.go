@@ -0,0 +1,45 @@
+package rts
+
+import "testing"
+
+func TestDefaultAreaCodeDBLookup(t *testing.T) {
+	db, err := DefaultAreaCodeDB()
+	if err != nil {
+		t.Fatalf("DefaultAreaCodeDB: %v", err)
+	}
+
+	info, ok := db.Lookup(1)
+	if !ok {
+		t.Fatal("expected code 1 to be found in the default dataset")
+	}
+	if info.CountyName != "Taipei City" {
+		t.Errorf("got county %q, want %q", info.CountyName, "Taipei City")
+	}
+
+	if _, ok := db.Lookup(65535); ok {
+		t.Error("expected unknown code 65535 to be absent")
+	}
+}
+
+func TestEnrich(t *testing.T) {
+	db, err := DefaultAreaCodeDB()
+	if err != nil {
+		t.Fatalf("DefaultAreaCodeDB: %v", err)
+	}
+
+	data := &RTSData{
+		AreaIntensities: []AreaIntensity{
+			{Code: 6, Intensity: 4.0},
+			{Code: 65535, Intensity: 1.0},
+		},
+	}
+
+	Enrich(data, db)
+
+	if data.AreaIntensities[0].Info == nil || data.AreaIntensities[0].Info.CountyName != "Kaohsiung City" {
+		t.Errorf("got %+v, want enriched Kaohsiung City", data.AreaIntensities[0])
+	}
+	if data.AreaIntensities[1].Info != nil {
+		t.Errorf("expected unknown code to stay unenriched, got %+v", data.AreaIntensities[1])
+	}
+}
@@ -0,0 +1,49 @@
+// Command rtstail connects to a live RTS feed and prints each decoded frame
+// as JSON as it arrives.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/watermelon1024/exptech-binary-rts-parser/golang/rtsstream"
+)
+
+func main() {
+	url := flag.String("url", "wss://rts.exptech.dev/websocket", "RTS WebSocket feed URL")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	frames, errs := rtsstream.Subscribe(ctx, *url)
+
+	for {
+		select {
+		case data, ok := <-frames:
+			if !ok {
+				return
+			}
+			jsonData, err := json.Marshal(data)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "rtstail: marshal frame: %v\n", err)
+				continue
+			}
+			fmt.Println(string(jsonData))
+
+		case err, ok := <-errs:
+			if !ok {
+				return
+			}
+			fmt.Fprintf(os.Stderr, "rtstail: %v\n", err)
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package rts
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func validMinimalFrame() []byte {
+	return []byte{
+		SupportedVersion,
+		0, 0, 0, 0, 0, // time40: Epoch
+		0, 0, // stationCount
+		0, 0, // intCount
+		0, 0, // reserved
+	}
+}
+
+func FuzzRTSParser(f *testing.F) {
+	f.Add(validMinimalFrame())
+
+	// truncated header
+	f.Add([]byte{SupportedVersion})
+	f.Add([]byte{SupportedVersion, 0, 0, 0})
+
+	// stationCount/intCount that would over-allocate before the
+	// remaining-bytes check
+	f.Add([]byte{
+		SupportedVersion,
+		0, 0, 0, 0, 0,
+		0xFF, 0xFF, // stationCount = 65535
+		0xFF, 0xFF, // intCount = 65535
+		0, 0,
+	})
+
+	// VarInt markers at end of stream
+	f.Add(append(append([]byte{
+		SupportedVersion,
+		0, 0, 0, 0, 0,
+		1, 0,
+		0, 0,
+		0, 0,
+	}, 0, 0, 0, 0), 0xFD)) // u32 id, then a bare 0xFD marker with no u16 payload
+	f.Add(append(append([]byte{
+		SupportedVersion,
+		0, 0, 0, 0, 0,
+		1, 0,
+		0, 0,
+		0, 0,
+	}, 0, 0, 0, 0), 0xFE)) // bare 0xFE marker with no u24 payload
+	f.Add(append(append([]byte{
+		SupportedVersion,
+		0, 0, 0, 0, 0,
+		1, 0,
+		0, 0,
+		0, 0,
+	}, 0, 0, 0, 0), 0xFF)) // undefined 0xFF marker
+
+	// negative 40-bit timestamp (all bits set)
+	f.Add([]byte{
+		SupportedVersion,
+		0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
+		0, 0,
+		0, 0,
+		0, 0,
+	})
+
+	// intensity byte with the alert bit (0x80) set
+	f.Add(append(append([]byte{
+		SupportedVersion,
+		0, 0, 0, 0, 0,
+		1, 0,
+		0, 0,
+		0, 0,
+	}, 1, 0, 0, 0, 10, 10), byte(0x80|50)))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Fatalf("Parse panicked on input %x: %v", data, r)
+			}
+		}()
+		_, _ = NewRTSParser(bytes.NewReader(data)).Parse()
+	})
+}
+
+func FuzzRTSRoundTrip(f *testing.F) {
+	f.Add(validMinimalFrame())
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Fatalf("round trip panicked on input %x: %v", data, r)
+			}
+		}()
+
+		first, err := NewRTSParser(bytes.NewReader(data)).Parse()
+		if err != nil {
+			return
+		}
+
+		var buf bytes.Buffer
+		w := NewRTSWriter(&buf)
+		if err := w.WriteHeader(first.Header); err != nil {
+			return
+		}
+		for _, s := range first.Stations {
+			if err := w.WriteStation(s); err != nil {
+				return
+			}
+		}
+		for _, a := range first.AreaIntensities {
+			if err := w.WriteAreaIntensity(a); err != nil {
+				return
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+
+		second, err := NewRTSParser(&buf).Parse()
+		if err != nil {
+			t.Fatalf("re-parse of re-encoded frame failed: %v", err)
+		}
+
+		if !reflect.DeepEqual(first, second) {
+			t.Fatalf("round trip mismatch:\n got  %+v\n want %+v", second, first)
+		}
+	})
+}
@@ -1,13 +1,10 @@
-package main
+package rts
 
 import (
-	"bytes"
 	"encoding/binary"
-	"encoding/json"
 	"fmt"
 	"io"
 	"math"
-	"os"
 )
 
 const (
@@ -36,6 +33,9 @@ type Station struct {
 type AreaIntensity struct {
 	Code      uint16  `json:"code"`
 	Intensity float64 `json:"intensity"`
+	// Info is populated by Enrich (or RTSParser.SetAreaCodeDB) and is nil
+	// until then.
+	Info *AreaInfo `json:"info,omitempty"`
 }
 
 type RTSData struct {
@@ -45,15 +45,53 @@ type RTSData struct {
 }
 
 type RTSParser struct {
-	reader io.Reader
+	reader     io.Reader
+	current    *Frame
+	areaCodeDB *AreaCodeDB
 }
 
 func NewRTSParser(r io.Reader) *RTSParser {
 	return &RTSParser{reader: r}
 }
 
+// SetAreaCodeDB opts Parse into enriching every AreaIntensity.Info via db,
+// so JSON output includes the human-readable area name and coordinates.
+func (p *RTSParser) SetAreaCodeDB(db *AreaCodeDB) {
+	p.areaCodeDB = db
+}
+
 // --- Helper Methods ---
 
+// minStationBytes, minAreaIntensityBytes are each record's smallest
+// possible on-wire size (1-byte VarInt form), used by checkRemaining to
+// reject a header whose declared counts can't possibly fit in what's left
+// of the underlying reader, before any station/area-intensity slice is
+// allocated.
+const (
+	minStationBytes       = 4 + 1 + 1 + 1 // ID + PGA + PGV + intensity/alert
+	minAreaIntensityBytes = 2 + 1         // code + intensity
+)
+
+// lenReader is implemented by bounded readers such as bytes.Reader and
+// strings.Reader.
+type lenReader interface {
+	Len() int
+}
+
+// checkRemaining rejects declaredBytes if the underlying reader reports
+// fewer bytes left than that. It's a no-op for readers that can't report
+// their remaining length (e.g. a network stream).
+func (p *RTSParser) checkRemaining(declaredBytes int) error {
+	lr, ok := p.reader.(lenReader)
+	if !ok {
+		return nil
+	}
+	if declaredBytes > lr.Len() {
+		return fmt.Errorf("rts: frame declares %d bytes of records but only %d remain", declaredBytes, lr.Len())
+	}
+	return nil
+}
+
 func (p *RTSParser) readExact(size int) ([]byte, error) {
 	buf := make([]byte, size)
 	// io.ReadFull make sure read exactly size, otherwise returns io.ErrUnexpectedEOF
@@ -163,129 +201,117 @@ func (p *RTSParser) readIntensityAlert() (float64, bool, error) {
 
 // --- Main Parse Logic ---
 
-func (p *RTSParser) Parse() (*RTSData, error) {
-	// Check Version
+// readHeader reads the fixed-size RTSHeader that starts every frame.
+func (p *RTSParser) readHeader() (RTSHeader, error) {
 	version, err := p.readU8()
 	if err != nil {
-		return nil, err
+		return RTSHeader{}, err
 	}
 	if version != SupportedVersion {
-		return nil, fmt.Errorf("unsupported RTS version: %d", version)
+		return RTSHeader{}, fmt.Errorf("unsupported RTS version: %d", version)
 	}
 
-	// Parse Header
 	timestampMs, err := p.readTime40()
 	if err != nil {
-		return nil, err
+		return RTSHeader{}, err
 	}
 
 	stationCount, err := p.readU16()
 	if err != nil {
-		return nil, err
+		return RTSHeader{}, err
 	}
 
 	intCount, err := p.readU16()
 	if err != nil {
-		return nil, err
+		return RTSHeader{}, err
 	}
 
 	reserved, err := p.readU16()
 	if err != nil {
-		return nil, err
+		return RTSHeader{}, err
 	}
 
-	header := RTSHeader{
+	declaredBytes := int(stationCount)*minStationBytes + int(intCount)*minAreaIntensityBytes
+	if err := p.checkRemaining(declaredBytes); err != nil {
+		return RTSHeader{}, err
+	}
+
+	return RTSHeader{
 		Version:      version,
 		TimestampMs:  timestampMs,
 		StationCount: stationCount,
 		IntCount:     intCount,
 		Reserved:     reserved,
-	}
-
-	// Parse Stations
-	stations := make([]Station, stationCount)
-	for i := 0; i < int(stationCount); i++ {
-		id, err := p.readU32()
-		if err != nil {
-			return nil, err
-		}
+	}, nil
+}
 
-		pga, err := p.readVarInt()
-		if err != nil {
+// NextFrame reads the next frame's header off the reader and returns a
+// Frame that pulls its stations and area intensities on demand. This lets
+// callers stream very long or continuously-appended feeds without
+// buffering an entire frame's records up front.
+//
+// If the previous Frame returned by NextFrame was not fully drained,
+// NextFrame discards its remaining records first, so the reader is always
+// left positioned at the start of the next frame.
+func (p *RTSParser) NextFrame() (*Frame, error) {
+	if p.current != nil {
+		if err := p.current.discard(); err != nil {
 			return nil, err
 		}
+	}
 
-		pgv, err := p.readVarInt()
-		if err != nil {
-			return nil, err
-		}
+	header, err := p.readHeader()
+	if err != nil {
+		return nil, err
+	}
 
-		intensity, isAlert, err := p.readIntensityAlert()
-		if err != nil {
-			return nil, err
-		}
+	frame := &Frame{Header: header, parser: p}
+	p.current = frame
+	return frame, nil
+}
 
-		stations[i] = Station{
-			ID:        id,
-			PGA:       pga,
-			PGV:       pgv,
-			Intensity: intensity,
-			IsAlert:   isAlert,
-		}
+// Parse reads a single frame in full, buffering all of its stations and
+// area intensities. It is a convenience wrapper around NextFrame for
+// callers that don't need streaming access.
+func (p *RTSParser) Parse() (*RTSData, error) {
+	frame, err := p.NextFrame()
+	if err != nil {
+		return nil, err
 	}
 
-	// Parse Area Intensities
-	areaInts := make([]AreaIntensity, intCount)
-	for i := 0; i < int(intCount); i++ {
-		code, err := p.readU16()
+	stations := make([]Station, 0, frame.Header.StationCount)
+	for {
+		station, err := frame.NextStation()
+		if err == io.EOF {
+			break
+		}
 		if err != nil {
 			return nil, err
 		}
+		stations = append(stations, station)
+	}
 
-		rawI, err := p.readU8()
+	areaInts := make([]AreaIntensity, 0, frame.Header.IntCount)
+	for {
+		areaInt, err := frame.NextAreaIntensity()
+		if err == io.EOF {
+			break
+		}
 		if err != nil {
 			return nil, err
 		}
-
-		// handle area intensity
-		val := (float64(rawI) / 10.0) - IntensityOffset
-		intensity := math.Round(val*10) / 10
-
-		areaInts[i] = AreaIntensity{
-			Code:      code,
-			Intensity: intensity,
-		}
+		areaInts = append(areaInts, areaInt)
 	}
 
-	return &RTSData{
-		Header:          header,
+	data := &RTSData{
+		Header:          frame.Header,
 		Stations:        stations,
 		AreaIntensities: areaInts,
-	}, nil
-}
-
-// --- Example Usage ---
-
-func main() {
-	// read example RTS binary data from "example.rts"
-	mockData, err := os.ReadFile("rts_example.bin")
-	if err != nil {
-		fmt.Printf("Error reading file: %v\n", err)
-		return
 	}
 
-	// Create Parser
-	reader := bytes.NewReader(mockData)
-	parser := NewRTSParser(reader)
-
-	// Execute parsing
-	data, err := parser.Parse()
-	if err != nil {
-		fmt.Printf("Error parsing data: %v\n", err)
-		return
+	if p.areaCodeDB != nil {
+		Enrich(data, p.areaCodeDB)
 	}
 
-	// Convert to JSON and output
-	jsonData, _ := json.MarshalIndent(data, "", "  ")
-	fmt.Println(string(jsonData))
+	return data, nil
 }
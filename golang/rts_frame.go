@@ -0,0 +1,109 @@
+package rts
+
+import (
+	"io"
+	"math"
+)
+
+// Frame is a handle to a single frame returned by RTSParser.NextFrame. Its
+// Header is decoded up front; its stations and area intensities are pulled
+// one at a time via NextStation/NextAreaIntensity so a caller never needs
+// to buffer a whole section at once.
+type Frame struct {
+	Header RTSHeader
+
+	parser       *RTSParser
+	stationsRead uint16
+	areaIntsRead uint16
+}
+
+// NextStation reads the next station record, returning io.EOF once
+// Header.StationCount records have been read.
+func (f *Frame) NextStation() (Station, error) {
+	if f.stationsRead >= f.Header.StationCount {
+		return Station{}, io.EOF
+	}
+
+	id, err := f.parser.readU32()
+	if err != nil {
+		return Station{}, err
+	}
+
+	pga, err := f.parser.readVarInt()
+	if err != nil {
+		return Station{}, err
+	}
+
+	pgv, err := f.parser.readVarInt()
+	if err != nil {
+		return Station{}, err
+	}
+
+	intensity, isAlert, err := f.parser.readIntensityAlert()
+	if err != nil {
+		return Station{}, err
+	}
+
+	f.stationsRead++
+
+	return Station{
+		ID:        id,
+		PGA:       pga,
+		PGV:       pgv,
+		Intensity: intensity,
+		IsAlert:   isAlert,
+	}, nil
+}
+
+// NextAreaIntensity reads the next area intensity record, returning io.EOF
+// once Header.IntCount records have been read.
+func (f *Frame) NextAreaIntensity() (AreaIntensity, error) {
+	if f.areaIntsRead >= f.Header.IntCount {
+		return AreaIntensity{}, io.EOF
+	}
+
+	code, err := f.parser.readU16()
+	if err != nil {
+		return AreaIntensity{}, err
+	}
+
+	rawI, err := f.parser.readU8()
+	if err != nil {
+		return AreaIntensity{}, err
+	}
+
+	val := (float64(rawI) / 10.0) - IntensityOffset
+	intensity := math.Round(val*10) / 10
+
+	f.areaIntsRead++
+
+	return AreaIntensity{
+		Code:      code,
+		Intensity: intensity,
+	}, nil
+}
+
+// discard reads and drops any stations/area intensities the caller left
+// unread, so the underlying reader ends up positioned at the start of the
+// next frame.
+func (f *Frame) discard() error {
+	for {
+		if _, err := f.NextStation(); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+	}
+
+	for {
+		if _, err := f.NextAreaIntensity(); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package rts
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestNextFrameStreaming(t *testing.T) {
+	frames := []*RTSData{
+		{
+			Header: RTSHeader{
+				Version:      SupportedVersion,
+				TimestampMs:  Epoch + 1,
+				StationCount: 2,
+				IntCount:     1,
+			},
+			Stations: []Station{
+				{ID: 1, PGA: 1.1, PGV: 2.2, Intensity: 3.0, IsAlert: false},
+				{ID: 2, PGA: 3.3, PGV: 4.4, Intensity: -2.0, IsAlert: true},
+			},
+			AreaIntensities: []AreaIntensity{
+				{Code: 99, Intensity: 5.0},
+			},
+		},
+		{
+			Header: RTSHeader{
+				Version:      SupportedVersion,
+				TimestampMs:  Epoch + 2,
+				StationCount: 1,
+				IntCount:     0,
+			},
+			Stations: []Station{
+				{ID: 3, PGA: 0.1, PGV: 0.2, Intensity: 0.0, IsAlert: false},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	for _, f := range frames {
+		if err := writeRTSData(NewRTSWriter(&buf), f); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	parser := NewRTSParser(&buf)
+
+	for i, want := range frames {
+		frame, err := parser.NextFrame()
+		if err != nil {
+			t.Fatalf("frame %d: NextFrame: %v", i, err)
+		}
+
+		var stations []Station
+		for {
+			s, err := frame.NextStation()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("frame %d: NextStation: %v", i, err)
+			}
+			stations = append(stations, s)
+		}
+
+		if len(stations) != len(want.Stations) {
+			t.Fatalf("frame %d: got %d stations, want %d", i, len(stations), len(want.Stations))
+		}
+	}
+
+	if _, err := parser.NextFrame(); err != io.EOF {
+		t.Fatalf("expected io.EOF after last frame, got %v", err)
+	}
+}
+
+func TestNextFrameSkipsUndrainedFrame(t *testing.T) {
+	frames := []*RTSData{
+		{
+			Header: RTSHeader{
+				Version:      SupportedVersion,
+				TimestampMs:  Epoch,
+				StationCount: 3,
+				IntCount:     2,
+			},
+			Stations: []Station{
+				{ID: 1}, {ID: 2}, {ID: 3},
+			},
+			AreaIntensities: []AreaIntensity{
+				{Code: 1, Intensity: 0}, {Code: 2, Intensity: 0},
+			},
+		},
+		{
+			Header: RTSHeader{
+				Version:      SupportedVersion,
+				TimestampMs:  Epoch + 1,
+				StationCount: 1,
+			},
+			Stations: []Station{{ID: 42}},
+		},
+	}
+
+	var buf bytes.Buffer
+	for _, f := range frames {
+		if err := writeRTSData(NewRTSWriter(&buf), f); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	parser := NewRTSParser(&buf)
+
+	if _, err := parser.NextFrame(); err != nil {
+		t.Fatalf("first NextFrame: %v", err)
+	}
+	// Intentionally don't drain the first frame's stations/area intensities.
+
+	second, err := parser.NextFrame()
+	if err != nil {
+		t.Fatalf("second NextFrame: %v", err)
+	}
+
+	s, err := second.NextStation()
+	if err != nil {
+		t.Fatalf("NextStation: %v", err)
+	}
+	if s.ID != 42 {
+		t.Fatalf("got station ID %d, want 42", s.ID)
+	}
+}
@@ -0,0 +1,176 @@
+package rts
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// minTime40, maxTime40 are the inclusive bounds of the signed 40-bit
+// epoch-offset stored on the wire.
+const (
+	minTime40 = -(int64(1) << 39)
+	maxTime40 = (int64(1) << 39) - 1
+)
+
+// maxVarInt is the largest raw integer a VarInt can encode (exclusive),
+// matching the 24-bit ceiling imposed by the 0xFE marker form.
+const maxVarInt = 1 << 24
+
+// RTSWriter encodes an RTSData back into the binary RTS wire format. It is
+// the inverse of RTSParser: WriteHeader/WriteStation/WriteAreaIntensity
+// emit exactly the bytes RTSParser.Parse expects to read back.
+type RTSWriter struct {
+	writer io.Writer
+}
+
+func NewRTSWriter(w io.Writer) *RTSWriter {
+	return &RTSWriter{writer: w}
+}
+
+// --- Helper Methods ---
+
+func (w *RTSWriter) writeU8(v uint8) error {
+	_, err := w.writer.Write([]byte{v})
+	return err
+}
+
+func (w *RTSWriter) writeU16(v uint16) error {
+	var buf [2]byte
+	binary.LittleEndian.PutUint16(buf[:], v)
+	_, err := w.writer.Write(buf[:])
+	return err
+}
+
+func (w *RTSWriter) writeU32(v uint32) error {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], v)
+	_, err := w.writer.Write(buf[:])
+	return err
+}
+
+// little-endian 40-bit signed integer with epoch offset
+func (w *RTSWriter) writeTime40(timestampMs int64) error {
+	raw := timestampMs - Epoch
+	if raw < minTime40 || raw > maxTime40 {
+		return fmt.Errorf("rts: timestamp %d out of signed 40-bit range", timestampMs)
+	}
+
+	var buf [5]byte
+	buf[0] = byte(raw)
+	buf[1] = byte(raw >> 8)
+	buf[2] = byte(raw >> 16)
+	buf[3] = byte(raw >> 24)
+	buf[4] = byte(raw >> 32)
+
+	_, err := w.writer.Write(buf[:])
+	return err
+}
+
+// variant-length integer encoding
+func (w *RTSWriter) writeVarInt(value float64) error {
+	rawVal := int64(math.Round(value * VarIntScale))
+	if rawVal < 0 || rawVal >= maxVarInt {
+		return fmt.Errorf("rts: varint value %v out of range", value)
+	}
+
+	switch {
+	case rawVal <= 0xFC:
+		return w.writeU8(uint8(rawVal))
+	case rawVal <= 0xFFFF:
+		if err := w.writeU8(0xFD); err != nil {
+			return err
+		}
+		return w.writeU16(uint16(rawVal))
+	default:
+		if err := w.writeU8(0xFE); err != nil {
+			return err
+		}
+		buf := []byte{byte(rawVal), byte(rawVal >> 8), byte(rawVal >> 16)}
+		_, err := w.writer.Write(buf)
+		return err
+	}
+}
+
+// writeIntensityAlert packs intensity and the alert flag into a single byte
+func (w *RTSWriter) writeIntensityAlert(intensity float64, isAlert bool) error {
+	raw := int64(math.Round((intensity + IntensityOffset) * 10))
+	if raw < 0 || raw > 0x7F {
+		return fmt.Errorf("rts: intensity %v out of range", intensity)
+	}
+
+	b := uint8(raw)
+	if isAlert {
+		b |= 0x80
+	}
+
+	return w.writeU8(b)
+}
+
+// --- Main Write Logic ---
+
+// WriteHeader writes the RTS frame header: version, timestamp and the
+// station/area-intensity counts that WriteStation/WriteAreaIntensity must
+// then satisfy.
+func (w *RTSWriter) WriteHeader(header RTSHeader) error {
+	if header.Version != SupportedVersion {
+		return fmt.Errorf("unsupported RTS version: %d", header.Version)
+	}
+
+	if err := w.writeU8(header.Version); err != nil {
+		return err
+	}
+
+	if err := w.writeTime40(header.TimestampMs); err != nil {
+		return err
+	}
+
+	if err := w.writeU16(header.StationCount); err != nil {
+		return err
+	}
+
+	if err := w.writeU16(header.IntCount); err != nil {
+		return err
+	}
+
+	return w.writeU16(header.Reserved)
+}
+
+// WriteStation writes a single station record.
+func (w *RTSWriter) WriteStation(s Station) error {
+	if err := w.writeU32(s.ID); err != nil {
+		return err
+	}
+
+	if err := w.writeVarInt(s.PGA); err != nil {
+		return err
+	}
+
+	if err := w.writeVarInt(s.PGV); err != nil {
+		return err
+	}
+
+	return w.writeIntensityAlert(s.Intensity, s.IsAlert)
+}
+
+// WriteAreaIntensity writes a single area intensity record.
+func (w *RTSWriter) WriteAreaIntensity(a AreaIntensity) error {
+	if err := w.writeU16(a.Code); err != nil {
+		return err
+	}
+
+	raw := int64(math.Round((a.Intensity + IntensityOffset) * 10))
+	if raw < 0 || raw > 0xFF {
+		return fmt.Errorf("rts: area intensity %v out of range", a.Intensity)
+	}
+
+	return w.writeU8(uint8(raw))
+}
+
+// Close finishes writing the frame. The RTS wire format has no trailer, so
+// Close is a no-op; it exists for symmetry with RTSParser and to keep
+// RTSWriter usable through the io.Closer interface.
+func (w *RTSWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,124 @@
+package rts
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// writeRTSData encodes a full RTSData frame, mirroring what RTSParser.Parse
+// decodes: header, then stations, then area intensities.
+func writeRTSData(w *RTSWriter, data *RTSData) error {
+	if err := w.WriteHeader(data.Header); err != nil {
+		return err
+	}
+
+	for _, s := range data.Stations {
+		if err := w.WriteStation(s); err != nil {
+			return err
+		}
+	}
+
+	for _, a := range data.AreaIntensities {
+		if err := w.WriteAreaIntensity(a); err != nil {
+			return err
+		}
+	}
+
+	return w.Close()
+}
+
+func TestRoundTrip(t *testing.T) {
+	cases := []*RTSData{
+		{
+			Header: RTSHeader{
+				Version:      SupportedVersion,
+				TimestampMs:  Epoch + 123456,
+				StationCount: 2,
+				IntCount:     1,
+			},
+			Stations: []Station{
+				{ID: 1001, PGA: 1.23, PGV: 0.45, Intensity: 4.5, IsAlert: true},
+				{ID: 1002, PGA: 999.99, PGV: 12.34, Intensity: -1.0, IsAlert: false},
+			},
+			AreaIntensities: []AreaIntensity{
+				{Code: 10020, Intensity: 6.0},
+			},
+		},
+		{
+			Header: RTSHeader{
+				Version:      SupportedVersion,
+				TimestampMs:  Epoch - 1,
+				StationCount: 0,
+				IntCount:     0,
+			},
+		},
+		{
+			// forces the 3-byte (0xFD+u16) and 4-byte (0xFE+u24) VarInt forms
+			Header: RTSHeader{
+				Version:      SupportedVersion,
+				TimestampMs:  Epoch + 7,
+				StationCount: 1,
+				IntCount:     0,
+			},
+			Stations: []Station{
+				{ID: 42, PGA: 400.0, PGV: 70000.0, Intensity: 9.6, IsAlert: true},
+			},
+		},
+	}
+
+	for i, want := range cases {
+		var buf bytes.Buffer
+		if err := writeRTSData(NewRTSWriter(&buf), want); err != nil {
+			t.Fatalf("case %d: write: %v", i, err)
+		}
+
+		got, err := NewRTSParser(&buf).Parse()
+		if err != nil {
+			t.Fatalf("case %d: parse: %v", i, err)
+		}
+
+		if got.Stations == nil {
+			got.Stations = []Station{}
+		}
+		if got.AreaIntensities == nil {
+			got.AreaIntensities = []AreaIntensity{}
+		}
+		if want.Stations == nil {
+			want.Stations = []Station{}
+		}
+		if want.AreaIntensities == nil {
+			want.AreaIntensities = []AreaIntensity{}
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("case %d: round trip mismatch:\n got  %+v\n want %+v", i, got, want)
+		}
+	}
+}
+
+func TestWriteTime40OutOfRange(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewRTSWriter(&buf)
+
+	err := w.WriteHeader(RTSHeader{
+		Version:     SupportedVersion,
+		TimestampMs: Epoch + (int64(1) << 40),
+	})
+	if err == nil {
+		t.Fatal("expected error for out-of-range timestamp, got nil")
+	}
+}
+
+func TestWriteVarIntOutOfRange(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewRTSWriter(&buf)
+
+	if err := w.WriteStation(Station{PGA: -1}); err == nil {
+		t.Fatal("expected error for negative VarInt, got nil")
+	}
+
+	if err := w.WriteStation(Station{PGA: float64(maxVarInt) / VarIntScale}); err == nil {
+		t.Fatal("expected error for out-of-range VarInt, got nil")
+	}
+}
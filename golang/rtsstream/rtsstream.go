@@ -0,0 +1,159 @@
+// Package rtsstream wraps RTSParser with a real-time transport client so
+// callers can consume a live feed of decoded RTS frames instead of parsing
+// a single buffered capture.
+package rtsstream
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	rts "github.com/watermelon1024/exptech-binary-rts-parser/golang"
+)
+
+const (
+	defaultInitialBackoff = 500 * time.Millisecond
+	defaultMaxBackoff     = 30 * time.Second
+)
+
+// Transport delivers raw, frame-boundary-aligned binary payloads from a
+// live source (a WebSocket, a Kafka/NATS topic, a raw TCP stream, ...).
+// Frames starts delivering on frames/errs and must stop and close both
+// channels once ctx is done.
+type Transport interface {
+	Frames(ctx context.Context) (frames <-chan []byte, errs <-chan error)
+}
+
+// Options configures Subscribe/SubscribeTransport's reconnect behavior.
+type Options struct {
+	// InitialBackoff is the delay before reconnecting after an attempt
+	// that delivered no frames. An attempt that delivered a frame resets
+	// the backoff and is followed by an immediate reconnect.
+	// Defaults to 500ms.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the exponential backoff between reconnect attempts.
+	// Defaults to 30s.
+	MaxBackoff time.Duration
+}
+
+func (o Options) withDefaults() Options {
+	if o.InitialBackoff <= 0 {
+		o.InitialBackoff = defaultInitialBackoff
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = defaultMaxBackoff
+	}
+	return o
+}
+
+// Subscribe connects to the ExpTech RTS WebSocket feed at url and streams
+// decoded frames on the returned channel until ctx is canceled. Transport
+// failures are reported on the error channel and trigger an automatic
+// reconnect with exponential backoff; decode failures for a single frame
+// are reported the same way without tearing down the connection.
+func Subscribe(ctx context.Context, url string, opts ...Options) (<-chan *rts.RTSData, <-chan error) {
+	return SubscribeTransport(ctx, NewWebSocketTransport(url), opts...)
+}
+
+// SubscribeTransport is like Subscribe but reads from a caller-supplied
+// Transport, letting a Kafka or NATS backend be swapped in for the default
+// WebSocket client.
+func SubscribeTransport(ctx context.Context, transport Transport, opts ...Options) (<-chan *rts.RTSData, <-chan error) {
+	opt := Options{}
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	opt = opt.withDefaults()
+
+	data := make(chan *rts.RTSData)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(data)
+		defer close(errs)
+
+		backoff := opt.InitialBackoff
+		for ctx.Err() == nil {
+			connected := runTransport(ctx, transport, data, errs)
+			if ctx.Err() != nil {
+				return
+			}
+
+			if connected {
+				backoff = opt.InitialBackoff
+				continue
+			}
+
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return
+			}
+
+			if backoff *= 2; backoff > opt.MaxBackoff {
+				backoff = opt.MaxBackoff
+			}
+		}
+	}()
+
+	return data, errs
+}
+
+// runTransport drains a single connection attempt, decoding and forwarding
+// frames until both of the transport's channels close — frames and
+// transportErrs are drained independently so a buffered error isn't lost
+// just because frames happened to close first in the same select. It
+// reports whether any frame was successfully received, which resets the
+// reconnect backoff.
+func runTransport(ctx context.Context, transport Transport, data chan<- *rts.RTSData, errs chan<- error) bool {
+	frames, transportErrs := transport.Frames(ctx)
+	connected := false
+
+	for frames != nil || transportErrs != nil {
+		select {
+		case <-ctx.Done():
+			return connected
+
+		case payload, ok := <-frames:
+			if !ok {
+				frames = nil
+				continue
+			}
+			connected = true
+
+			parsed, err := rts.NewRTSParser(bytes.NewReader(payload)).Parse()
+			if err != nil {
+				sendErr(ctx, errs, fmt.Errorf("rtsstream: decode frame: %w", err))
+				continue
+			}
+
+			select {
+			case data <- parsed:
+			case <-ctx.Done():
+				return connected
+			}
+
+		case err, ok := <-transportErrs:
+			if !ok {
+				transportErrs = nil
+				continue
+			}
+			if err != nil {
+				sendErr(ctx, errs, err)
+			}
+		}
+	}
+
+	return connected
+}
+
+func sendErr(ctx context.Context, errs chan<- error, err error) {
+	select {
+	case errs <- err:
+	case <-ctx.Done():
+	default:
+		// Caller isn't keeping up with errors; drop rather than block the
+		// frame pipeline on a full, unbuffered-beyond-1 channel.
+	}
+}
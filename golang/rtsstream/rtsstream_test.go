@@ -0,0 +1,205 @@
+package rtsstream
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// validFrame is a minimal, fully-valid RTS frame: version 1, timestamp at
+// Epoch, zero stations and zero area intensities.
+var validFrame = []byte{
+	1,
+	0, 0, 0, 0, 0,
+	0, 0,
+	0, 0,
+	0, 0,
+}
+
+// attemptSpec describes one fakeTransport.Frames call: the frames (if any)
+// to deliver, followed by an optional transport error, before the attempt
+// closes.
+type attemptSpec struct {
+	frames [][]byte
+	err    error
+}
+
+// fakeTransport replays a fixed sequence of attemptSpecs, recording when
+// each attempt started so tests can assert on reconnect timing.
+type fakeTransport struct {
+	specs []attemptSpec
+	calls []time.Time
+}
+
+func (f *fakeTransport) Frames(ctx context.Context) (<-chan []byte, <-chan error) {
+	f.calls = append(f.calls, time.Now())
+
+	frames := make(chan []byte, 1)
+	errs := make(chan error, 1)
+
+	if len(f.calls) <= len(f.specs) {
+		spec := f.specs[len(f.calls)-1]
+		for _, fr := range spec.frames {
+			frames <- fr
+		}
+		if spec.err != nil {
+			errs <- spec.err
+		}
+	}
+	close(frames)
+	close(errs)
+
+	return frames, errs
+}
+
+func TestSubscribeTransportResetsBackoffAfterSuccess(t *testing.T) {
+	ft := &fakeTransport{specs: []attemptSpec{
+		{err: fmt.Errorf("attempt 1 failed")}, // triggers a backoff sleep
+		{frames: [][]byte{validFrame}},        // connects, resets backoff
+		{err: fmt.Errorf("attempt 3 failed")}, // should sleep ~InitialBackoff again, not 2x
+		{err: fmt.Errorf("attempt 4 failed")},
+	}}
+
+	const initialBackoff = 30 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), 400*time.Millisecond)
+	defer cancel()
+
+	data, errs := SubscribeTransport(ctx, ft, Options{
+		InitialBackoff: initialBackoff,
+		MaxBackoff:     time.Second,
+	})
+
+	for {
+		select {
+		case _, ok := <-data:
+			if !ok {
+				data = nil
+			}
+		case _, ok := <-errs:
+			if !ok {
+				errs = nil
+			}
+		case <-ctx.Done():
+		}
+		if data == nil && errs == nil {
+			break
+		}
+	}
+
+	if len(ft.calls) < 4 {
+		t.Fatalf("got %d attempts, want at least 4 (ctx: %v)", len(ft.calls), ctx.Err())
+	}
+
+	gapAfterSuccess := ft.calls[2].Sub(ft.calls[1])
+	gapAfterReset := ft.calls[3].Sub(ft.calls[2])
+
+	if gapAfterSuccess > initialBackoff/2 {
+		t.Errorf("reconnect after a successful attempt should be immediate, took %v", gapAfterSuccess)
+	}
+	// Without resetting backoff on success, this gap would be ~2x
+	// initialBackoff (continuing attempt 1's doubling).
+	if gapAfterReset > initialBackoff*3/2 {
+		t.Errorf("backoff was not reset after the successful attempt: gap %v, want ~%v", gapAfterReset, initialBackoff)
+	}
+}
+
+// TestSubscribeTransportDoesNotDropBufferedErrorOnFramesClose reproduces a
+// transport that only ever fails to dial: attemptSpec.err is buffered on
+// errs and both channels are closed in the same Frames call, so a select
+// in runTransport could pick the now-closed frames case first and exit
+// before the buffered error is read. Every attempt's error must still
+// reach the caller.
+func TestSubscribeTransportDoesNotDropBufferedErrorOnFramesClose(t *testing.T) {
+	const n = 200
+	specs := make([]attemptSpec, n)
+	for i := range specs {
+		specs[i] = attemptSpec{err: fmt.Errorf("attempt %d failed", i)}
+	}
+	ft := &fakeTransport{specs: specs}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	data, errs := SubscribeTransport(ctx, ft, Options{
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+	})
+
+	received := 0
+	for data != nil || errs != nil {
+		select {
+		case _, ok := <-data:
+			if !ok {
+				data = nil
+			}
+		case e, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			if e != nil {
+				received++
+				if received == n {
+					cancel()
+				}
+			}
+		}
+	}
+
+	if received != n {
+		t.Fatalf("received %d/%d attempt errors; some were dropped when frames closed alongside a buffered error", received, n)
+	}
+}
+
+// floodTransport keeps a single connection "open" and sends a burst of
+// transport errors without ever delivering a frame, to check that a caller
+// who never drains the error channel doesn't stall the pipeline.
+type floodTransport struct {
+	n    int
+	sent int32
+}
+
+func (f *floodTransport) Frames(ctx context.Context) (<-chan []byte, <-chan error) {
+	frames := make(chan []byte)
+	errs := make(chan error)
+
+	go func() {
+		defer close(frames)
+		defer close(errs)
+
+		for i := 0; i < f.n; i++ {
+			select {
+			case errs <- fmt.Errorf("transport error %d", i):
+				atomic.AddInt32(&f.sent, 1)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return frames, errs
+}
+
+func TestSubscribeTransportDoesNotBlockOnUndrainedErrors(t *testing.T) {
+	ft := &floodTransport{n: 50}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// Deliberately don't read from either returned channel.
+	SubscribeTransport(ctx, ft, Options{InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+
+	deadline := time.After(500 * time.Millisecond)
+	for {
+		if atomic.LoadInt32(&ft.sent) >= int32(ft.n) {
+			return
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("only %d/%d errors were drained; the pipeline appears to be stuck on the undrained error channel", atomic.LoadInt32(&ft.sent), ft.n)
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+}
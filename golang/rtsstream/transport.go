@@ -0,0 +1,197 @@
+package rtsstream
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/gorilla/websocket"
+)
+
+// NewWebSocketTransport returns a Transport that dials the ExpTech RTS feed
+// at url and delivers each WebSocket message as one frame. WebSocket
+// already frames messages for us, so no extra delimiting is needed.
+func NewWebSocketTransport(url string) Transport {
+	return &webSocketTransport{url: url}
+}
+
+type webSocketTransport struct {
+	url string
+}
+
+func (t *webSocketTransport) Frames(ctx context.Context) (<-chan []byte, <-chan error) {
+	frames := make(chan []byte)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(frames)
+		defer close(errs)
+
+		conn, _, err := websocket.DefaultDialer.DialContext(ctx, t.url, nil)
+		if err != nil {
+			errs <- fmt.Errorf("rtsstream: dial %s: %w", t.url, err)
+			return
+		}
+		defer conn.Close()
+
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			select {
+			case <-ctx.Done():
+				conn.Close()
+			case <-done:
+			}
+		}()
+
+		for {
+			_, payload, err := conn.ReadMessage()
+			if err != nil {
+				if ctx.Err() == nil {
+					errs <- fmt.Errorf("rtsstream: read %s: %w", t.url, err)
+				}
+				return
+			}
+
+			select {
+			case frames <- payload:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return frames, errs
+}
+
+// Framing selects how StreamTransport splits a raw byte stream into frames.
+type Framing int
+
+const (
+	// FramingLengthPrefixed frames are a 4-byte little-endian length
+	// prefix followed by that many bytes of frame payload.
+	FramingLengthPrefixed Framing = iota
+	// FramingDelimiter frames are separated by a fixed delimiter byte.
+	FramingDelimiter
+)
+
+// defaultMaxFrameBytes caps a single frame so a corrupted or hostile stream
+// can't force a multi-gigabyte allocation — a declared FramingLengthPrefixed
+// length beyond the cap is rejected outright, and a FramingDelimiter frame
+// that grows past the cap without producing the delimiter is rejected too.
+// It's generous relative to a real RTS frame (bounded to a couple MiB by the
+// uint16 station/area-intensity counts).
+const defaultMaxFrameBytes = 16 << 20 // 16 MiB
+
+// NewStreamTransport returns a Transport for raw byte-stream sources (e.g.
+// a plain TCP socket) that don't already deliver message-bounded payloads.
+// dial is called once per connection attempt; the returned ReadCloser is
+// closed when ctx is done or the connection fails. delimiter is only used
+// when framing is FramingDelimiter. maxFrameBytes caps how large a single
+// frame is allowed to be, for either framing mode; 0 uses
+// defaultMaxFrameBytes.
+func NewStreamTransport(dial func(ctx context.Context) (io.ReadCloser, error), framing Framing, delimiter byte, maxFrameBytes int) Transport {
+	if maxFrameBytes <= 0 {
+		maxFrameBytes = defaultMaxFrameBytes
+	}
+	return &streamTransport{dial: dial, framing: framing, delimiter: delimiter, maxFrameBytes: maxFrameBytes}
+}
+
+type streamTransport struct {
+	dial          func(ctx context.Context) (io.ReadCloser, error)
+	framing       Framing
+	delimiter     byte
+	maxFrameBytes int
+}
+
+func (t *streamTransport) Frames(ctx context.Context) (<-chan []byte, <-chan error) {
+	frames := make(chan []byte)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(frames)
+		defer close(errs)
+
+		conn, err := t.dial(ctx)
+		if err != nil {
+			errs <- fmt.Errorf("rtsstream: dial: %w", err)
+			return
+		}
+		defer conn.Close()
+
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			select {
+			case <-ctx.Done():
+				conn.Close()
+			case <-done:
+			}
+		}()
+
+		r := bufio.NewReader(conn)
+		for {
+			payload, err := t.readFrame(r)
+			if err != nil {
+				if ctx.Err() == nil && err != io.EOF {
+					errs <- fmt.Errorf("rtsstream: read frame: %w", err)
+				}
+				return
+			}
+
+			select {
+			case frames <- payload:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return frames, errs
+}
+
+func (t *streamTransport) readFrame(r *bufio.Reader) ([]byte, error) {
+	if t.framing == FramingDelimiter {
+		return t.readDelimited(r)
+	}
+
+	var lenBuf [4]byte
+	if _, err := io.ReadFull(r, lenBuf[:]); err != nil {
+		return nil, err
+	}
+
+	length := binary.LittleEndian.Uint32(lenBuf[:])
+	if length > uint32(t.maxFrameBytes) {
+		return nil, fmt.Errorf("rtsstream: frame length %d exceeds max %d", length, t.maxFrameBytes)
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+// readDelimited consumes bytes up to and including t.delimiter and returns
+// the frame without the delimiter. Unlike r.ReadBytes, it bails out once the
+// frame grows past maxFrameBytes instead of buffering an unbounded amount of
+// a stream that never produces the delimiter.
+func (t *streamTransport) readDelimited(r *bufio.Reader) ([]byte, error) {
+	buf := make([]byte, 0, 256)
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		if b == t.delimiter {
+			return buf, nil
+		}
+		if len(buf) >= t.maxFrameBytes {
+			return nil, fmt.Errorf("rtsstream: frame exceeds max %d bytes without finding delimiter", t.maxFrameBytes)
+		}
+		buf = append(buf, b)
+	}
+}
@@ -0,0 +1,66 @@
+package rtsstream
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+)
+
+func TestStreamTransportReadFrameLengthPrefixed(t *testing.T) {
+	var buf bytes.Buffer
+	buf.Write([]byte{5, 0, 0, 0}) // little-endian uint32 length prefix
+	buf.WriteString("hello")
+
+	st := &streamTransport{framing: FramingLengthPrefixed, maxFrameBytes: defaultMaxFrameBytes}
+	payload, err := st.readFrame(bufio.NewReader(&buf))
+	if err != nil {
+		t.Fatalf("readFrame: %v", err)
+	}
+	if string(payload) != "hello" {
+		t.Errorf("got %q, want %q", payload, "hello")
+	}
+}
+
+func TestStreamTransportReadFrameLengthPrefixedRejectsOversized(t *testing.T) {
+	var buf bytes.Buffer
+	buf.Write([]byte{0, 0, 0, 1}) // length = 1<<24, far beyond a tiny cap
+
+	st := &streamTransport{framing: FramingLengthPrefixed, maxFrameBytes: 1024}
+	if _, err := st.readFrame(bufio.NewReader(&buf)); err == nil {
+		t.Fatal("expected an error for a length prefix exceeding maxFrameBytes, got nil")
+	}
+}
+
+func TestStreamTransportReadFrameDelimiter(t *testing.T) {
+	var buf bytes.Buffer
+	buf.WriteString("first\nsecond\n")
+
+	st := &streamTransport{framing: FramingDelimiter, delimiter: '\n', maxFrameBytes: defaultMaxFrameBytes}
+	r := bufio.NewReader(&buf)
+
+	first, err := st.readFrame(r)
+	if err != nil {
+		t.Fatalf("readFrame (1st): %v", err)
+	}
+	if string(first) != "first" {
+		t.Errorf("got %q, want %q", first, "first")
+	}
+
+	second, err := st.readFrame(r)
+	if err != nil {
+		t.Fatalf("readFrame (2nd): %v", err)
+	}
+	if string(second) != "second" {
+		t.Errorf("got %q, want %q", second, "second")
+	}
+}
+
+func TestStreamTransportReadFrameDelimiterRejectsOversized(t *testing.T) {
+	var buf bytes.Buffer
+	buf.WriteString("this frame never hits a delimiter before the cap")
+
+	st := &streamTransport{framing: FramingDelimiter, delimiter: '\n', maxFrameBytes: 8}
+	if _, err := st.readFrame(bufio.NewReader(&buf)); err == nil {
+		t.Fatal("expected an error for a frame exceeding maxFrameBytes without a delimiter, got nil")
+	}
+}